@@ -0,0 +1,41 @@
+// Package metrics holds the Prometheus collectors updateccl exposes on
+// /metrics, so operators can alert on stale data or upstream failures
+// instead of relying on log-watching alone.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// RowsInserted counts rows upserted into ccl3 across all runs.
+	RowsInserted = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "updateccl_rows_inserted_total",
+		Help: "Total number of ccl3 rows inserted or updated.",
+	})
+
+	// APIErrors counts fetch failures, labeled by provider.
+	APIErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "updateccl_api_errors_total",
+		Help: "Total number of provider fetch errors.",
+	}, []string{"provider"})
+
+	// FetchDuration records how long each provider's Fetch call takes.
+	FetchDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "updateccl_fetch_duration_seconds",
+		Help:    "Duration of provider fetch calls in seconds.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+func init() {
+	prometheus.MustRegister(RowsInserted, APIErrors, FetchDuration)
+}
+
+// Handler serves the registered collectors for a /metrics route.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,65 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// BYMA fetches spot prices from the Bolsas y Mercados Argentinos API. It is
+// typically placed ahead of MatbaRofex in the priority chain so its CCL
+// overrides the MatbaRofex one when both report the same date.
+type BYMA struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewBYMA returns a BYMA provider pointed at the public API.
+func NewBYMA() *BYMA {
+	return &BYMA{
+		BaseURL:    "https://www.byma.com.ar/api/spot-prices",
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+func (p *BYMA) Name() string { return "byma" }
+
+// Fetch delegates to fetchWithRetry, which splits [from, to] into day-sized
+// sub-windows when it spans more than three days and retries each window
+// with exponential backoff, fetching each one via fetchWindow.
+func (p *BYMA) Fetch(ctx context.Context, from, to time.Time) ([]SpotPrice, error) {
+	return fetchWithRetry(ctx, from, to, p.Name(), p.fetchWindow)
+}
+
+func (p *BYMA) fetchWindow(ctx context.Context, from, to time.Time) ([]SpotPrice, error) {
+	params := fmt.Sprintf("?from=%s&to=%s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.BaseURL+params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status code %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data []SpotPrice `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	for i := range result.Data {
+		result.Data[i].Source = p.Name()
+	}
+
+	return result.Data, nil
+}
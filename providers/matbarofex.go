@@ -0,0 +1,64 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// MatbaRofex fetches spot prices from the MatbaRofex CEM API. This is the
+// original, and still default, source for CCL/CCL3.
+type MatbaRofex struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewMatbaRofex returns a MatbaRofex provider pointed at the public API.
+func NewMatbaRofex() *MatbaRofex {
+	return &MatbaRofex{
+		BaseURL:    "https://apicem.matbarofex.com.ar/api/v2/spot-prices",
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+func (p *MatbaRofex) Name() string { return "matbarofex" }
+
+// Fetch delegates to fetchWithRetry, which splits [from, to] into day-sized
+// sub-windows when it spans more than three days and retries each window
+// with exponential backoff, fetching each one via fetchWindow.
+func (p *MatbaRofex) Fetch(ctx context.Context, from, to time.Time) ([]SpotPrice, error) {
+	return fetchWithRetry(ctx, from, to, p.Name(), p.fetchWindow)
+}
+
+func (p *MatbaRofex) fetchWindow(ctx context.Context, from, to time.Time) ([]SpotPrice, error) {
+	params := fmt.Sprintf("?spot=&from=%s&to=%s&page=1&pageSize=32000", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.BaseURL+params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status code %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data []SpotPrice `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	for i := range result.Data {
+		result.Data[i].Source = p.Name()
+	}
+
+	return result.Data, nil
+}
@@ -0,0 +1,39 @@
+package providers
+
+// MergeByPriority deduplicates quotes from multiple providers by (DateTime,
+// Spot), keeping the value from whichever provider appears first in
+// priority. priority lists provider Name()s from highest to lowest
+// precedence; a provider not listed is treated as lowest priority. When
+// several unlisted providers report the same key, which of them wins is not
+// defined, since results is a map. The order of the returned rows is not
+// defined either.
+func MergeByPriority(results map[string][]SpotPrice, priority []string) []SpotPrice {
+	rank := make(map[string]int, len(priority))
+	for i, name := range priority {
+		rank[name] = i
+	}
+
+	type key struct{ dateTime, spot string }
+	best := make(map[key]SpotPrice)
+	bestRank := make(map[key]int)
+
+	for provider, rows := range results {
+		r, ok := rank[provider]
+		if !ok {
+			r = len(priority)
+		}
+		for _, row := range rows {
+			k := key{row.DateTime, row.Spot}
+			if existing, seen := bestRank[k]; !seen || r < existing {
+				best[k] = row
+				bestRank[k] = r
+			}
+		}
+	}
+
+	merged := make([]SpotPrice, 0, len(best))
+	for _, row := range best {
+		merged = append(merged, row)
+	}
+	return merged
+}
@@ -0,0 +1,45 @@
+package providers
+
+import "testing"
+
+func TestMergeByPriorityPrefersHigherPriorityProvider(t *testing.T) {
+	results := map[string][]SpotPrice{
+		"matbarofex": {{DateTime: "2026-07-01", Spot: "CCL", NormalizedPrice: "100"}},
+		"byma":       {{DateTime: "2026-07-01", Spot: "CCL", NormalizedPrice: "105"}},
+	}
+
+	merged := MergeByPriority(results, []string{"byma", "matbarofex"})
+
+	if len(merged) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(merged))
+	}
+	if merged[0].NormalizedPrice != "105" {
+		t.Fatalf("expected byma's value to win, got %+v", merged[0])
+	}
+}
+
+func TestMergeByPriorityKeepsDisjointRows(t *testing.T) {
+	results := map[string][]SpotPrice{
+		"matbarofex": {{DateTime: "2026-07-01", Spot: "CCL", NormalizedPrice: "100"}},
+		"byma":       {{DateTime: "2026-07-02", Spot: "CCL", NormalizedPrice: "105"}},
+	}
+
+	merged := MergeByPriority(results, []string{"byma", "matbarofex"})
+
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(merged))
+	}
+}
+
+func TestMergeByPriorityUnlistedProviderIsLowestPriority(t *testing.T) {
+	results := map[string][]SpotPrice{
+		"matbarofex": {{DateTime: "2026-07-01", Spot: "CCL", NormalizedPrice: "100"}},
+		"unranked":   {{DateTime: "2026-07-01", Spot: "CCL", NormalizedPrice: "999"}},
+	}
+
+	merged := MergeByPriority(results, []string{"matbarofex"})
+
+	if len(merged) != 1 || merged[0].NormalizedPrice != "100" {
+		t.Fatalf("expected matbarofex to win over an unranked provider, got %+v", merged)
+	}
+}
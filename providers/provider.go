@@ -0,0 +1,31 @@
+// Package providers defines the pluggable spot/FX price source interface and
+// the concrete providers that implement it.
+package providers
+
+import (
+	"context"
+	"time"
+)
+
+// SpotPrice is a single spot quote as returned by a Provider. Source is
+// populated by the provider itself and is persisted alongside the quote so
+// downstream consumers can trace where a value came from.
+type SpotPrice struct {
+	DateTime        string `json:"dateTime"`
+	NormalizedPrice string `json:"normalizedPrice"`
+	Spot            string `json:"spot"`
+	Source          string `json:"-"`
+}
+
+// Provider is a pluggable source of spot/FX prices. Implementations fetch
+// quotes for a date window and tag every row with their own Name() as the
+// provenance source.
+type Provider interface {
+	// Name identifies the provider, e.g. "matbarofex", "byma". It is stored
+	// verbatim in the ccl3.source column.
+	Name() string
+
+	// Fetch returns the spot prices available in [from, to]. Implementations
+	// should respect ctx cancellation/deadlines.
+	Fetch(ctx context.Context, from, to time.Time) ([]SpotPrice, error)
+}
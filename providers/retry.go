@@ -0,0 +1,118 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// retryConfig controls how a provider retries a transient failure.
+type retryConfig struct {
+	MaxAttempts int
+	Timeout     time.Duration
+	BaseBackoff time.Duration
+}
+
+func defaultRetryConfig() retryConfig {
+	return retryConfig{
+		MaxAttempts: 3,
+		Timeout:     10 * time.Second,
+		BaseBackoff: 250 * time.Millisecond,
+	}
+}
+
+// withRetry calls fn up to cfg.MaxAttempts times, each attempt bounded by
+// cfg.Timeout, backing off exponentially with jitter between attempts. It
+// returns the last error if every attempt fails, or nil on the first
+// success.
+func withRetry(ctx context.Context, cfg retryConfig, fn func(ctx context.Context) error) error {
+	var lastErr error
+	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+		lastErr = fn(attemptCtx)
+		cancel()
+
+		if lastErr == nil {
+			return nil
+		}
+		if attempt == cfg.MaxAttempts-1 {
+			break
+		}
+
+		backoff := cfg.BaseBackoff << attempt
+		jitter := time.Duration(rand.Int63n(int64(backoff) + 1))
+		select {
+		case <-time.After(backoff + jitter):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return lastErr
+}
+
+// fetchWithRetry drives the common Fetch shape shared by the HTTP-backed
+// providers: split [from, to] via dayWindows (day-sized sub-windows when the
+// range spans more than three days), retry each window with exponential
+// backoff via withRetry, and accumulate rows across windows. Rows from
+// successful windows are always returned; if any window ultimately fails
+// after retries, fetchWithRetry returns the partial rows alongside a
+// summarised error listing the failed windows (tagged with name). The failed
+// windows are not recorded anywhere else: a later run starts from the day
+// after the latest stored date, so a failed window that precedes a stored
+// day is not fetched again.
+func fetchWithRetry(ctx context.Context, from, to time.Time, name string, fetchWindow func(ctx context.Context, from, to time.Time) ([]SpotPrice, error)) ([]SpotPrice, error) {
+	cfg := defaultRetryConfig()
+
+	var rows []SpotPrice
+	var failedWindows []string
+
+	for _, window := range dayWindows(from, to) {
+		windowFrom, windowTo := window[0], window[1]
+
+		var windowRows []SpotPrice
+		err := withRetry(ctx, cfg, func(attemptCtx context.Context) error {
+			fetched, ferr := fetchWindow(attemptCtx, windowFrom, windowTo)
+			if ferr != nil {
+				return ferr
+			}
+			windowRows = fetched
+			return nil
+		})
+		if err != nil {
+			failedWindows = append(failedWindows, fmt.Sprintf("%s..%s: %v",
+				windowFrom.Format("2006-01-02"), windowTo.Format("2006-01-02"), err))
+			continue
+		}
+
+		rows = append(rows, windowRows...)
+	}
+
+	if len(failedWindows) > 0 {
+		return rows, fmt.Errorf("%s: %d sub-window(s) failed after retries: %s",
+			name, len(failedWindows), strings.Join(failedWindows, "; "))
+	}
+	return rows, nil
+}
+
+// dayWindows splits [from, to] into day-sized sub-windows when the range
+// exceeds splitThreshold, so a single bad day can't lose the whole range.
+// Ranges at or under the threshold are returned as a single window.
+func dayWindows(from, to time.Time) [][2]time.Time {
+	const splitThreshold = 3 * 24 * time.Hour
+
+	if to.Sub(from) <= splitThreshold {
+		return [][2]time.Time{{from, to}}
+	}
+
+	var windows [][2]time.Time
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		if end.After(to) {
+			end = to
+		}
+		windows = append(windows, [2]time.Time{day, end})
+	}
+	return windows
+}
@@ -0,0 +1,76 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDayWindowsUnderThresholdIsSingleWindow(t *testing.T) {
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+
+	windows := dayWindows(from, to)
+
+	if len(windows) != 1 {
+		t.Fatalf("expected 1 window, got %d: %+v", len(windows), windows)
+	}
+	if windows[0][0] != from || windows[0][1] != to {
+		t.Fatalf("expected window to span the full range, got %+v", windows[0])
+	}
+}
+
+func TestDayWindowsOverThresholdSplitsByDay(t *testing.T) {
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 5)
+
+	windows := dayWindows(from, to)
+
+	if len(windows) != 5 {
+		t.Fatalf("expected 5 day-sized windows, got %d: %+v", len(windows), windows)
+	}
+	if windows[0][0] != from {
+		t.Fatalf("expected first window to start at %v, got %v", from, windows[0][0])
+	}
+	if windows[len(windows)-1][1] != to {
+		t.Fatalf("expected last window to end at %v, got %v", to, windows[len(windows)-1][1])
+	}
+}
+
+func TestWithRetrySucceedsWithoutExhaustingAttempts(t *testing.T) {
+	attempts := 0
+	cfg := retryConfig{MaxAttempts: 3, Timeout: time.Second, BaseBackoff: time.Millisecond}
+
+	err := withRetry(context.Background(), cfg, func(ctx context.Context) error {
+		attempts++
+		if attempts < 2 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestWithRetryReturnsLastErrorAfterExhaustingAttempts(t *testing.T) {
+	attempts := 0
+	cfg := retryConfig{MaxAttempts: 3, Timeout: time.Second, BaseBackoff: time.Millisecond}
+
+	err := withRetry(context.Background(), cfg, func(ctx context.Context) error {
+		attempts++
+		return errors.New("permanent")
+	})
+
+	if err == nil {
+		t.Fatal("expected an error after exhausting all attempts")
+	}
+	if attempts != cfg.MaxAttempts {
+		t.Fatalf("expected %d attempts, got %d", cfg.MaxAttempts, attempts)
+	}
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Calendar answers whether a given day is a trading day on the Argentine
+// market. Weekends are always non-trading; holidays are loaded from a JSON
+// file of {"date": "name"} entries.
+type Calendar struct {
+	holidays map[string]string // "2006-01-02" -> holiday name
+}
+
+// NewCalendar returns an empty calendar with no holidays loaded.
+func NewCalendar() *Calendar {
+	return &Calendar{holidays: map[string]string{}}
+}
+
+// LoadCalendarFile loads a holiday calendar from a JSON file shaped as
+// {"2026-05-25": "Día de la Revolución de Mayo", ...}.
+func LoadCalendarFile(path string) (*Calendar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading calendar %s: %w", path, err)
+	}
+
+	var holidays map[string]string
+	if err := json.Unmarshal(data, &holidays); err != nil {
+		return nil, fmt.Errorf("parsing calendar %s: %w", path, err)
+	}
+
+	return &Calendar{holidays: holidays}, nil
+}
+
+// IsTradingDay reports whether day is a trading day, and if not, why
+// ("weekend" or "holiday: <name>").
+func (c *Calendar) IsTradingDay(day time.Time) (ok bool, reason string) {
+	if wd := day.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false, "weekend"
+	}
+	if name, isHoliday := c.holidays[day.Format("2006-01-02")]; isHoliday {
+		return false, fmt.Sprintf("holiday: %s", name)
+	}
+	return true, ""
+}
@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsTradingDaySkipsWeekends(t *testing.T) {
+	c := NewCalendar()
+	saturday := time.Date(2026, 7, 25, 12, 0, 0, 0, time.UTC)
+
+	ok, reason := c.IsTradingDay(saturday)
+
+	if ok {
+		t.Fatal("expected Saturday to not be a trading day")
+	}
+	if reason != "weekend" {
+		t.Fatalf("expected reason %q, got %q", "weekend", reason)
+	}
+}
+
+func TestIsTradingDaySkipsHolidays(t *testing.T) {
+	c := &Calendar{holidays: map[string]string{
+		"2026-07-09": "Día de la Independencia",
+	}}
+	holiday := time.Date(2026, 7, 9, 12, 0, 0, 0, time.UTC)
+
+	ok, reason := c.IsTradingDay(holiday)
+
+	if ok {
+		t.Fatal("expected the holiday to not be a trading day")
+	}
+	if reason != "holiday: Día de la Independencia" {
+		t.Fatalf("unexpected reason: %q", reason)
+	}
+}
+
+func TestIsTradingDayAllowsOrdinaryWeekday(t *testing.T) {
+	c := NewCalendar()
+	monday := time.Date(2026, 7, 27, 12, 0, 0, 0, time.UTC)
+
+	ok, reason := c.IsTradingDay(monday)
+
+	if !ok {
+		t.Fatalf("expected Monday to be a trading day, got reason %q", reason)
+	}
+}
@@ -0,0 +1,87 @@
+// Package scheduler runs downloadCCL as a resident daemon on a cron spec,
+// skipping non-trading days according to an Argentine market calendar.
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// Job is the work a Scheduler fires on each cron tick.
+type Job func()
+
+// Scheduler fires Job on a cron spec, suppressing runs on non-trading days.
+type Scheduler struct {
+	cron     *cron.Cron
+	calendar *Calendar
+	job      Job
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	nextRun time.Time
+}
+
+// New builds a Scheduler. spec is a standard 6-field cron expression
+// (seconds first), e.g. "0 */30 11-18 * * MON-FRI". logger receives a
+// structured line for every skipped run, alongside the reason ("weekend" or
+// "holiday: <name>").
+func New(spec string, calendar *Calendar, job Job, logger *zap.Logger) (*Scheduler, error) {
+	s := &Scheduler{
+		cron:     cron.New(cron.WithSeconds()),
+		calendar: calendar,
+		job:      job,
+		logger:   logger,
+	}
+
+	_, err := s.cron.AddFunc(spec, s.tick)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Scheduler) tick() {
+	now := time.Now()
+	if ok, reason := s.calendar.IsTradingDay(now); !ok {
+		s.logger.Info("skipping scheduled run", zap.String("reason", reason))
+		s.recordNextRun()
+		return
+	}
+
+	s.job()
+	s.recordNextRun()
+}
+
+func (s *Scheduler) recordNextRun() {
+	entries := s.cron.Entries()
+	if len(entries) == 0 {
+		return
+	}
+	s.mu.Lock()
+	s.nextRun = entries[0].Next
+	s.mu.Unlock()
+}
+
+// Start begins firing the job on the cron spec.
+func (s *Scheduler) Start() {
+	// cron computes each entry's Next only once it is running, so record it
+	// after Start rather than before.
+	s.cron.Start()
+	s.recordNextRun()
+}
+
+// Stop stops the scheduler, waiting for any in-flight run to finish.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
+
+// NextRun returns the next scheduled firing time, for the /status endpoint.
+func (s *Scheduler) NextRun() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nextRun
+}
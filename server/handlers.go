@@ -0,0 +1,139 @@
+package server
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// cclRow is the wire shape returned by /ccl and /ccl/latest, in both JSON
+// and CSV form.
+type cclRow struct {
+	Date   string  `json:"date"`
+	CCL    float64 `json:"ccl"`
+	CCL3   float64 `json:"ccl3"`
+	Source string  `json:"source"`
+}
+
+func (s *Server) handleHealthz(c echo.Context) error {
+	if err := s.db.Ping(); err != nil {
+		return c.JSON(http.StatusServiceUnavailable, echo.Map{"status": "down", "error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, echo.Map{"status": "ok"})
+}
+
+func (s *Server) handleStatus(c echo.Context) error {
+	if s.scheduler == nil {
+		return c.JSON(http.StatusOK, echo.Map{"scheduler": "disabled"})
+	}
+	return c.JSON(http.StatusOK, echo.Map{
+		"scheduler": "running",
+		"next_run":  s.scheduler.NextRun(),
+	})
+}
+
+func (s *Server) handleCCLRange(c echo.Context) error {
+	from := c.QueryParam("from")
+	to := c.QueryParam("to")
+	if from == "" {
+		from = "1970-01-01"
+	}
+	if to == "" {
+		to = time.Now().Format("2006-01-02")
+	}
+
+	rows, err := s.db.Query(
+		"SELECT date, ccl, ccl3, source FROM ccl3 WHERE date BETWEEN $1 AND $2 ORDER BY date",
+		from, to,
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer rows.Close()
+
+	data, err := scanCCLRows(rows)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return respond(c, data)
+}
+
+func (s *Server) handleCCLLatest(c echo.Context) error {
+	rows, err := s.db.Query("SELECT date, ccl, ccl3, source FROM ccl3 ORDER BY date DESC LIMIT 1")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	defer rows.Close()
+
+	data, err := scanCCLRows(rows)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if len(data) == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "no data yet")
+	}
+
+	return respond(c, data)
+}
+
+func (s *Server) handleRefresh(c echo.Context) error {
+	if s.refresh == nil {
+		return echo.NewHTTPError(http.StatusNotImplemented, "refresh not configured")
+	}
+	if err := s.refresh(); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusAccepted, echo.Map{"status": "refreshed"})
+}
+
+// respond negotiates JSON (default) or CSV via ?format=csv or an
+// Accept: text/csv header.
+func respond(c echo.Context, rows []cclRow) error {
+	if c.QueryParam("format") == "csv" || c.Request().Header.Get("Accept") == "text/csv" {
+		return respondCSV(c, rows)
+	}
+	return c.JSON(http.StatusOK, rows)
+}
+
+func respondCSV(c echo.Context, rows []cclRow) error {
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+	defer w.Flush()
+
+	if err := w.Write([]string{"date", "ccl", "ccl3", "source"}); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := w.Write([]string{
+			row.Date,
+			strconv.FormatFloat(row.CCL, 'f', -1, 64),
+			strconv.FormatFloat(row.CCL3, 'f', -1, 64),
+			row.Source,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanCCLRows(rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+}) ([]cclRow, error) {
+	var out []cclRow
+	for rows.Next() {
+		var row cclRow
+		if err := rows.Scan(&row.Date, &row.CCL, &row.CCL3, &row.Source); err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,90 @@
+// Package server exposes updateccl's data over a long-running HTTP service,
+// for deployments that want to query CCL/CCL3 as a microservice instead of
+// re-running the batch job.
+package server
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+
+	"github.com/jmtruffa/updateccl/metrics"
+)
+
+// RefreshFunc triggers an on-demand fetch-and-upsert cycle, the same one the
+// batch job runs on its own schedule.
+type RefreshFunc func() error
+
+// StatusProvider reports when the resident scheduler will next fire, for the
+// /status endpoint. *scheduler.Scheduler satisfies this.
+type StatusProvider interface {
+	NextRun() time.Time
+}
+
+// Server wires the echo router to the database and the refresh hook.
+type Server struct {
+	echo      *echo.Echo
+	db        *sql.DB
+	refresh   RefreshFunc
+	jwtSecret []byte
+	scheduler StatusProvider
+}
+
+// New builds a Server. jwtSecret authenticates the write endpoints
+// (currently just POST /ccl/refresh); pass nil to disable auth, e.g. in
+// tests.
+func New(db *sql.DB, refresh RefreshFunc, jwtSecret []byte) *Server {
+	s := &Server{
+		echo:      echo.New(),
+		db:        db,
+		refresh:   refresh,
+		jwtSecret: jwtSecret,
+	}
+	s.routes()
+	return s
+}
+
+// SetScheduler attaches the resident cron scheduler so /status can report
+// its next firing time. Call it before Start.
+func (s *Server) SetScheduler(sched StatusProvider) {
+	s.scheduler = sched
+}
+
+func (s *Server) routes() {
+	s.echo.HideBanner = true
+
+	s.echo.GET("/healthz", s.handleHealthz)
+	s.echo.GET("/status", s.handleStatus)
+	s.echo.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+	s.echo.GET("/ccl", s.handleCCLRange)
+	s.echo.GET("/ccl/latest", s.handleCCLLatest)
+
+	refreshGroup := s.echo.Group("/ccl")
+	if len(s.jwtSecret) > 0 {
+		refreshGroup.Use(echojwt.WithConfig(echojwt.Config{
+			SigningKey: s.jwtSecret,
+		}))
+	}
+	refreshGroup.POST("/refresh", s.handleRefresh)
+}
+
+// Start runs the server until the context is cancelled, then shuts it down
+// gracefully (giving in-flight requests up to 10s to finish).
+func (s *Server) Start(ctx context.Context, addr string) error {
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		s.echo.Shutdown(shutdownCtx)
+	}()
+
+	err := s.echo.Start(addr)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
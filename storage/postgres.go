@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// postgresStore persists ccl3 rows via pq's CopyIn bulk COPY protocol into a
+// staging table, then a single upsert, so backfills over an overlapping
+// window are fast and safe to re-run.
+type postgresStore struct {
+	db *sql.DB
+}
+
+func newPostgresStore(dsn string) (Store, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening postgres: %w", err)
+	}
+	return &postgresStore{db: db}, nil
+}
+
+func (s *postgresStore) LastDate() (time.Time, error) {
+	return scanLastDate(s.db, "SELECT MAX(date) FROM ccl3")
+}
+
+func (s *postgresStore) Insert(rows []Row) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`CREATE TEMP TABLE ccl3_staging (
+		date date,
+		ccl double precision,
+		ccl3 double precision,
+		source text
+	) ON COMMIT DROP`); err != nil {
+		return fmt.Errorf("create staging table: %w", err)
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn("ccl3_staging", "date", "ccl", "ccl3", "source"))
+	if err != nil {
+		return fmt.Errorf("prepare copy-in: %w", err)
+	}
+
+	for _, row := range rows {
+		if _, err := stmt.Exec(row.Date, row.CCL, row.CCL3, row.Source); err != nil {
+			stmt.Close()
+			return fmt.Errorf("copy row %s: %w", row.Date, err)
+		}
+	}
+
+	if _, err := stmt.Exec(); err != nil {
+		stmt.Close()
+		return fmt.Errorf("flush copy-in: %w", err)
+	}
+
+	if err := stmt.Close(); err != nil {
+		return fmt.Errorf("close copy-in: %w", err)
+	}
+
+	if _, err := tx.Exec(`INSERT INTO ccl3 (date, ccl, ccl3, source)
+		SELECT date, ccl, ccl3, source FROM ccl3_staging
+		ON CONFLICT (date) DO UPDATE SET
+			ccl = EXCLUDED.ccl,
+			ccl3 = EXCLUDED.ccl3,
+			source = EXCLUDED.source`); err != nil {
+		return fmt.Errorf("upsert from staging: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (s *postgresStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteStore persists ccl3 rows to a local SQLite file. It trades the
+// Postgres store's bulk COPY for a plain upsert loop, which is plenty fast
+// at SQLite's typical local/CI scale and keeps the driver dependency-free of
+// Postgres-only features.
+type sqliteStore struct {
+	db *sql.DB
+}
+
+func newSQLiteStore(dsn string) (Store, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite3: %w", err)
+	}
+
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS ccl3 (
+		date TEXT PRIMARY KEY,
+		ccl REAL,
+		ccl3 REAL,
+		source TEXT
+	)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ensuring ccl3 table: %w", err)
+	}
+
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) LastDate() (time.Time, error) {
+	// date is stored as TEXT; MAX() over it loses the declared-type tracking
+	// mattn/go-sqlite3 relies on to hand back a time.Time, so it comes back
+	// as a plain string (or NULL on an empty table) and has to be parsed by
+	// hand instead of sharing Postgres's scanLastDate.
+	var lastDate sql.NullString
+	if err := s.db.QueryRow("SELECT MAX(date) FROM ccl3").Scan(&lastDate); err != nil {
+		return time.Time{}, fmt.Errorf("query last date: %w", err)
+	}
+	if !lastDate.Valid {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse("2006-01-02", lastDate.String)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing last date %q: %w", lastDate.String, err)
+	}
+	return parsed, nil
+}
+
+func (s *sqliteStore) Insert(rows []Row) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO ccl3 (date, ccl, ccl3, source) VALUES (?, ?, ?, ?)
+		ON CONFLICT (date) DO UPDATE SET ccl = excluded.ccl, ccl3 = excluded.ccl3, source = excluded.source`)
+	if err != nil {
+		return fmt.Errorf("prepare upsert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, row := range rows {
+		if _, err := stmt.Exec(row.Date, row.CCL, row.CCL3, row.Source); err != nil {
+			return fmt.Errorf("upsert row %s: %w", row.Date, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSQLiteStoreInsertThenLastDate(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "ccl3.db")
+
+	store, err := Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatalf("opening store: %v", err)
+	}
+	defer store.Close()
+
+	lastDate, err := store.LastDate()
+	if err != nil {
+		t.Fatalf("LastDate on empty table: %v", err)
+	}
+	if !lastDate.IsZero() {
+		t.Fatalf("expected zero time for empty table, got %v", lastDate)
+	}
+
+	rows := []Row{
+		{Date: "2026-07-01", CCL: 1000, CCL3: 1010, Source: "matbarofex"},
+		{Date: "2026-07-02", CCL: 1005, CCL3: 1015, Source: "matbarofex"},
+	}
+	if err := store.Insert(rows); err != nil {
+		t.Fatalf("inserting rows: %v", err)
+	}
+
+	lastDate, err = store.LastDate()
+	if err != nil {
+		t.Fatalf("LastDate after insert: %v", err)
+	}
+
+	want := time.Date(2026, 7, 2, 0, 0, 0, 0, time.UTC)
+	if !lastDate.Equal(want) {
+		t.Fatalf("expected last date %v, got %v", want, lastDate)
+	}
+}
@@ -0,0 +1,54 @@
+// Package storage abstracts the database backend downloadCCL writes to, so
+// the tool can run against Postgres in production or SQLite locally/in CI
+// without provisioning a server.
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Row is a single date's CCL/CCL3 quote, tagged with the provider it came
+// from.
+type Row struct {
+	Date   string
+	CCL    float64
+	CCL3   float64
+	Source string
+}
+
+// Store is the persistence boundary downloadCCL relies on: find where the
+// last run left off, then write the new rows idempotently.
+type Store interface {
+	// LastDate returns the most recent date already stored in ccl3, or the
+	// zero time if the table is empty.
+	LastDate() (time.Time, error)
+
+	// Insert upserts rows into ccl3, keyed by date.
+	Insert(rows []Row) error
+
+	// Close releases the underlying connection.
+	Close() error
+}
+
+// Open selects and connects to a Store based on driver ("postgres" or
+// "sqlite3") and dsn. This is normally wired from the DB_DRIVER env var.
+func Open(driver, dsn string) (Store, error) {
+	switch driver {
+	case "postgres", "":
+		return newPostgresStore(dsn)
+	case "sqlite3":
+		return newSQLiteStore(dsn)
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", driver)
+	}
+}
+
+func scanLastDate(db *sql.DB, query string) (time.Time, error) {
+	var lastDate sql.NullTime
+	if err := db.QueryRow(query).Scan(&lastDate); err != nil {
+		return time.Time{}, fmt.Errorf("query last date: %w", err)
+	}
+	return lastDate.Time, nil
+}
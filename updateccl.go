@@ -1,19 +1,28 @@
 package main
 
 import (
+	"context"
 	"database/sql"
-	"encoding/json"
 	"fmt"
-	"log"
-	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
-	_ "github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/jmtruffa/updateccl/metrics"
+	"github.com/jmtruffa/updateccl/providers"
+	"github.com/jmtruffa/updateccl/scheduler"
+	"github.com/jmtruffa/updateccl/server"
+	"github.com/jmtruffa/updateccl/storage"
 )
 
+// logger is the process-wide structured logger, initialized in main.
+var logger *zap.Logger
+
 var (
 	dbUser     = os.Getenv("POSTGRES_USER")
 	dbPassword = os.Getenv("POSTGRES_PASSWORD")
@@ -24,72 +33,120 @@ var (
 
 var databaseURL = fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", dbUser, dbPassword, dbHost, dbPort, dbName)
 
-type SpotPrice struct {
-	DateTime        string `json:"dateTime"`
-	NormalizedPrice string `json:"normalizedPrice"`
-	Spot            string `json:"spot"`
+// openStore opens the configured Store. DB_DRIVER selects the backend
+// ("postgres", the default, or "sqlite3"); DB_DSN overrides the connection
+// string, falling back to databaseURL for Postgres so existing deployments
+// need no changes.
+func openStore() (storage.Store, error) {
+	driver := os.Getenv("DB_DRIVER")
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" && (driver == "" || driver == "postgres") {
+		dsn = databaseURL
+	}
+	return storage.Open(driver, dsn)
 }
 
-func queryAPI(startDate, endDate time.Time) ([]SpotPrice, error) {
-	apiURL := "https://apicem.matbarofex.com.ar/api/v2/spot-prices"
-	params := fmt.Sprintf("?spot=&from=%s&to=%s&page=1&pageSize=32000", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
-	resp, err := http.Get(apiURL + params)
-	if err != nil {
-		return nil, err
+// registeredProviders lists the active price sources, highest priority
+// first. BYMA is consulted before MatbaRofex so its CCL overrides
+// MatbaRofex's when both report a value for the same date. Any CSV/HTTP
+// scraper feeds configured via CSV_PROVIDER_CONFIGS are appended last, so
+// users can add new price feeds without editing this function.
+func registeredProviders() []providers.Provider {
+	provs := []providers.Provider{
+		providers.NewBYMA(),
+		providers.NewMatbaRofex(),
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status code %d", resp.StatusCode)
+	for _, path := range csvProviderConfigPaths() {
+		cfg, err := providers.LoadCSVConfig(path)
+		if err != nil {
+			logger.Warn("skipping csv provider config", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		provs = append(provs, providers.NewCSV(cfg))
 	}
 
-	var result struct {
-		Data []SpotPrice `json:"data"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	return provs
+}
+
+// csvProviderConfigPaths reads CSV_PROVIDER_CONFIGS, a colon-separated list
+// of YAML files, each describing one CSV provider to register.
+func csvProviderConfigPaths() []string {
+	raw := os.Getenv("CSV_PROVIDER_CONFIGS")
+	if raw == "" {
+		return nil
 	}
+	return strings.Split(raw, ":")
+}
 
-	return result.Data, nil
+func priorityNames(provs []providers.Provider) []string {
+	names := make([]string, len(provs))
+	for i, p := range provs {
+		names[i] = p.Name()
+	}
+	return names
 }
 
-func downloadCCL() {
-	currentTime := time.Now().Format("2006-01-02 15:04:05")
-	fmt.Println("------------------------------------")
-	fmt.Printf("Actualizando CCL...%s\n", currentTime)
+// downloadCCL runs one fetch-and-upsert cycle. It returns an error instead
+// of aborting the process on failure: it is called both from the one-shot
+// CLI entrypoint and, via the scheduler, from inside the long-running
+// `serve` process, where a transient DB hiccup must not take down the HTTP
+// service along with it.
+func downloadCCL() error {
+	start := time.Now()
+	windowStart, windowEnd := start, start
+	defer func() {
+		logger.Info("ccl refresh finished", zap.Int64("duration_ms", time.Since(start).Milliseconds()))
+	}()
 
-	db, err := sql.Open("postgres", databaseURL)
+	store, err := openStore()
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		return fmt.Errorf("failed to open store: %w", err)
 	}
-	defer db.Close()
+	defer store.Close()
 
-	var lastDate sql.NullTime
-	err = db.QueryRow("SELECT MAX(date) FROM ccl3").Scan(&lastDate)
+	lastDate, err := store.LastDate()
 	if err != nil {
-		log.Fatalf("Failed to query last date: %v", err)
+		return fmt.Errorf("failed to query last date: %w", err)
 	}
 
-	startDate := lastDate.Time.AddDate(0, 0, 1)
+	startDate := lastDate.AddDate(0, 0, 1)
 	endDate := time.Now()
+	windowStart, windowEnd = startDate, endDate
 
 	if startDate.After(endDate) || startDate.Equal(endDate) {
-		fmt.Println("No hay necesidad de actualizar datos")
-		return
+		logger.Info("no update needed", zap.Time("window_start", windowStart), zap.Time("window_end", windowEnd))
+		return nil
 	}
 
-	data, err := queryAPI(startDate, endDate)
-	if err != nil {
-		log.Fatalf("Failed to query API: %v", err)
+	provs := registeredProviders()
+	ctx := context.Background()
+	byProvider := make(map[string][]providers.SpotPrice, len(provs))
+	for _, p := range provs {
+		fetchStart := time.Now()
+		rows, err := p.Fetch(ctx, startDate, endDate)
+		metrics.FetchDuration.Observe(time.Since(fetchStart).Seconds())
+		if err != nil {
+			metrics.APIErrors.WithLabelValues(p.Name()).Inc()
+			logger.Warn("provider fetch reported errors, keeping partial results for next run",
+				zap.String("provider", p.Name()), zap.Int("rows_fetched", len(rows)), zap.Error(err))
+		} else {
+			logger.Info("provider fetch succeeded", zap.String("provider", p.Name()), zap.Int("rows_fetched", len(rows)))
+		}
+		if len(rows) > 0 {
+			byProvider[p.Name()] = rows
+		}
 	}
 
+	data := providers.MergeByPriority(byProvider, priorityNames(provs))
+
 	if len(data) == 0 {
-		fmt.Println("No data to insert. The API call returned an empty response. Estamos en fin de semana o feriado?")
-		return
+		logger.Info("no data to insert, estamos en fin de semana o feriado?", zap.Time("window_start", windowStart), zap.Time("window_end", windowEnd))
+		return nil
 	}
 
 	// Filter rows with 'spot' starting with 'CCL'
-	filteredData := []SpotPrice{}
+	filteredData := []providers.SpotPrice{}
 	for _, row := range data {
 		if strings.HasPrefix(row.Spot, "CCL") {
 			filteredData = append(filteredData, row)
@@ -97,82 +154,146 @@ func downloadCCL() {
 	}
 
 	if len(filteredData) == 0 {
-		fmt.Println("No hay datos para insertar ya que no hay un 'spot' con 'CCL'")
-		return
+		logger.Info("no hay datos para insertar ya que no hay un 'spot' con 'CCL'")
+		return nil
 	}
 
-	// Pivot data into a map: DateTime -> Spot -> NormalizedPrice
-	pivotData := make(map[string]map[string]float64)
+	// Pivot data into a map: DateTime -> Spot -> {price, source}
+	type priced struct {
+		value  float64
+		source string
+	}
+	pivotData := make(map[string]map[string]priced)
 	for _, row := range filteredData {
 		if _, exists := pivotData[row.DateTime]; !exists {
-			pivotData[row.DateTime] = make(map[string]float64)
+			pivotData[row.DateTime] = make(map[string]priced)
 		}
 		normalizedPrice, err := strconv.ParseFloat(row.NormalizedPrice, 64)
 		if err != nil {
-			log.Printf("Skipping row with invalid normalizedPrice: %v", err)
+			logger.Warn("skipping row with invalid normalizedPrice", zap.String("dateTime", row.DateTime), zap.Error(err))
 			continue
 		}
-		pivotData[row.DateTime][row.Spot] = normalizedPrice
+		pivotData[row.DateTime][row.Spot] = priced{value: normalizedPrice, source: row.Source}
 	}
 
 	// Combine into 'ccl' and 'ccl3'
-	insertData := []struct {
-		Date string
-		CCL  float64
-		CCL3 float64
-	}{}
+	insertData := []storage.Row{}
 	for dateTime, spots := range pivotData {
 		var ccl, ccl3 float64
+		var source string
 		// CCL: Prioridad CCL > CCL3
 		if val, exists := spots["CCL"]; exists {
-			ccl = val
+			ccl = val.value
+			source = val.source
 		} else if val, exists := spots["CCL3"]; exists {
-			ccl = val
+			ccl = val.value
+			source = val.source
 		}
 		// CCL3: Solo el valor de CCL3 (0 si no existe)
 		if val, exists := spots["CCL3"]; exists {
-			ccl3 = val
+			ccl3 = val.value
+			if source == "" {
+				source = val.source
+			}
 		}
 		// Agregar siempre, incluso si CCL o CCL3 son 0
-		insertData = append(insertData, struct {
-			Date string
-			CCL  float64
-			CCL3 float64
-		}{Date: dateTime, CCL: ccl, CCL3: ccl3})
+		insertData = append(insertData, storage.Row{Date: dateTime, CCL: ccl, CCL3: ccl3, Source: source})
 	}
 
 	if len(insertData) == 0 {
-		fmt.Println("No hay datos válidos para insertar después de combinar")
-		return
+		logger.Info("no hay datos válidos para insertar después de combinar")
+		return nil
 	}
 
-	// Insert data into the database
-	tx, err := db.Begin()
-	if err != nil {
-		log.Fatalf("Failed to begin transaction: %v", err)
+	if err := store.Insert(insertData); err != nil {
+		return fmt.Errorf("failed to upsert rows: %w", err)
+	}
+	metrics.RowsInserted.Add(float64(len(insertData)))
+
+	logger.Info("rows inserted", zap.Int("rows_inserted", len(insertData)), zap.Time("window_start", windowStart), zap.Time("window_end", windowEnd))
+	return nil
+}
+
+// serve starts the long-running HTTP service mode (`updateccl serve`),
+// exposing the same data the batch job writes, and shuts down gracefully on
+// SIGTERM/SIGINT.
+func serve() {
+	// The HTTP handlers query Postgres directly and don't go through the
+	// storage.Store abstraction downloadCCL uses, so a DB_DRIVER pointed at
+	// another backend would have the scheduler writing one database while
+	// /ccl, /ccl/latest and /healthz silently read another. Refuse to start
+	// rather than serve from the wrong store.
+	if driver := os.Getenv("DB_DRIVER"); driver != "" && driver != "postgres" {
+		logger.Fatal("serve only supports the postgres driver; DB_DRIVER is set to a different backend",
+			zap.String("driver", driver))
 	}
 
-	stmt, err := tx.Prepare("INSERT INTO ccl3 (date, ccl, ccl3) VALUES ($1, $2, $3)")
+	db, err := sql.Open("postgres", databaseURL)
 	if err != nil {
-		log.Fatalf("Failed to prepare statement: %v", err)
+		logger.Fatal("failed to connect to database", zap.Error(err))
 	}
-	defer stmt.Close()
+	defer db.Close()
+
+	srv := server.New(db, downloadCCL, []byte(os.Getenv("JWT_SECRET")))
+
+	if spec := os.Getenv("CRON_SPEC"); spec != "" {
+		calendar := loadCalendar(os.Getenv("MARKET_CALENDAR_FILE"))
 
-	for _, row := range insertData {
-		_, err := stmt.Exec(row.Date, row.CCL, row.CCL3)
+		sched, err := scheduler.New(spec, calendar, func() {
+			if err := downloadCCL(); err != nil {
+				logger.Error("scheduled ccl refresh failed", zap.Error(err))
+			}
+		}, logger)
 		if err != nil {
-			tx.Rollback()
-			log.Fatalf("Failed to insert row: %v", err)
+			logger.Fatal("failed to build scheduler", zap.Error(err))
 		}
+		srv.SetScheduler(sched)
+
+		sched.Start()
+		defer sched.Stop()
 	}
 
-	if err := tx.Commit(); err != nil {
-		log.Fatalf("Failed to commit transaction: %v", err)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	addr := os.Getenv("LISTEN_ADDR")
+	if addr == "" {
+		addr = ":8080"
 	}
 
-	fmt.Printf("Inserted %d rows\n", len(insertData))
+	if err := srv.Start(ctx, addr); err != nil {
+		logger.Fatal("server failed", zap.Error(err))
+	}
+}
+
+// loadCalendar loads the market-holiday calendar from path, falling back to
+// a calendar with no holidays (weekends are still always skipped) if path
+// is empty or fails to load.
+func loadCalendar(path string) *scheduler.Calendar {
+	if path == "" {
+		return scheduler.NewCalendar()
+	}
+	calendar, err := scheduler.LoadCalendarFile(path)
+	if err != nil {
+		logger.Warn("failed to load market calendar, running with weekends-only", zap.String("path", path), zap.Error(err))
+		return scheduler.NewCalendar()
+	}
+	return calendar
 }
 
 func main() {
-	downloadCCL()
+	var err error
+	logger, err = zap.NewProduction()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize logger: %v", err))
+	}
+	defer logger.Sync()
+
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		serve()
+		return
+	}
+	if err := downloadCCL(); err != nil {
+		logger.Fatal("ccl refresh failed", zap.Error(err))
+	}
 }